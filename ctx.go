@@ -0,0 +1,39 @@
+package taskgroup
+
+import "context"
+
+// CtxFnT is a task function that receives a context derived from the
+// group's, instead of closing over the group's Ctx() itself.
+type CtxFnT[T any] func(ctx context.Context) (T, error)
+
+// CtxFn is just an alias of CtxFnT[any], kept for backward compatibility.
+type CtxFn = CtxFnT[any]
+
+// GoCtx is like Go, except f receives a context derived from the group's
+// ctx instead of having to close over it. If Option.TaskTimeout is set,
+// that context carries its own deadline; when f observes it and returns
+// it as its error, that error is normalized to context.DeadlineExceeded.
+// An unrelated error f returns is left untouched even if the deadline has
+// also passed by the time f returns, and still counts toward
+// Option.MaxErrorCount like any other task error.
+//
+// If you call it after calling Fed method, task will be rejected and panic will occur!!!
+func (g *group[T]) GoCtx(f CtxFnT[T]) {
+	g.Go(func() (T, error) {
+		// Deriving the context here, rather than before Go's Option.Limit
+		// gate, means the timeout clock starts when the task is actually
+		// dispatched, not while it's still queued waiting for a slot.
+		ctx := g.ctx
+		if g.taskTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(g.ctx, g.taskTimeout)
+			defer cancel()
+		}
+
+		data, err := f(ctx)
+		if err != nil && err == ctx.Err() && ctx.Err() == context.DeadlineExceeded {
+			err = context.DeadlineExceeded
+		}
+		return data, err
+	})
+}
@@ -0,0 +1,112 @@
+package taskgroup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// taskgroup GoCtx basic test
+func TestGoCtxBasic(t *testing.T) {
+
+	loop(func() {
+
+		g, ctx := WithContextT[int](context.Background(), &Option{Limit: 5})
+		defer g.Cancel()
+
+		g.GoCtx(func(taskCtx context.Context) (int, error) {
+			assert.NoError(t, taskCtx.Err())
+			assert.Equal(t, ctx, taskCtx)
+			return 42, nil
+		})
+
+		g.Fed()
+
+		var got int
+		for p := range g.Result() {
+			assert.NoError(t, p.Err)
+			got = p.Data
+		}
+		assert.Equal(t, 42, got)
+	})
+}
+
+// taskgroup GoCtx with TaskTimeout reports context.DeadlineExceeded
+func TestGoCtxTaskTimeout(t *testing.T) {
+
+	loop(func() {
+
+		g := New(&Option{TaskTimeout: time.Millisecond})
+
+		g.GoCtx(func(taskCtx context.Context) (interface{}, error) {
+			<-taskCtx.Done()
+			return nil, taskCtx.Err()
+		})
+
+		g.Fed()
+
+		var err error
+		for p := range g.Result() {
+			err = p.Err
+		}
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+// taskgroup GoCtx must not discard an unrelated task error just because the
+// TaskTimeout deadline has also passed by the time the task returns.
+func TestGoCtxPreservesUnrelatedError(t *testing.T) {
+
+	loop(func() {
+
+		myErr := errors.New("validation failed: bad record")
+
+		g := New(&Option{TaskTimeout: 20 * time.Millisecond})
+
+		g.GoCtx(func(taskCtx context.Context) (interface{}, error) {
+			time.Sleep(25 * time.Millisecond)
+			return nil, myErr
+		})
+
+		g.Fed()
+
+		var err error
+		for p := range g.Result() {
+			err = p.Err
+		}
+		assert.Equal(t, myErr, err)
+	})
+}
+
+// taskgroup GoCtx's TaskTimeout clock starts once a task is dispatched, not
+// while it's still queued behind Option.Limit.
+func TestGoCtxTaskTimeoutStartsAtDispatch(t *testing.T) {
+
+	g := New(&Option{Limit: 1, TaskTimeout: 30 * time.Millisecond})
+	defer g.Cancel()
+
+	// the limit is smaller than the task count, so scheduling must be
+	// wrapped in a goroutine or the unconsumed Result() channel deadlocks.
+	go func() {
+		g.Go(func() (interface{}, error) {
+			time.Sleep(50 * time.Millisecond)
+			return nil, nil
+		})
+
+		g.GoCtx(func(taskCtx context.Context) (interface{}, error) {
+			return nil, taskCtx.Err()
+		})
+
+		g.Fed()
+	}()
+
+	count := 0
+	for p := range g.Result() {
+		assert.NoError(t, p.Err)
+		count++
+	}
+	assert.Equal(t, 2, count)
+}
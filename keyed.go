@@ -0,0 +1,79 @@
+package taskgroup
+
+import "sync/atomic"
+
+// inflight tracks a task submitted through GoKeyed that is still running,
+// along with how many callers are waiting on its result, modeled on
+// singleflight.Group's inflight call bookkeeping.
+type inflight[T any] struct {
+	waiters int
+}
+
+// GoKeyed is like Go, except concurrent submissions sharing the same key
+// collapse into a single execution of f; every caller that shared the key
+// receives a copy of the resulting Payload on Result(), with Shared set to
+// true once more than one caller joined it. This is useful for debouncing
+// identical work, e.g. cache warms or RPC coalescing.
+//
+// If you call it after calling Fed method, task will be rejected and panic will occur!!!
+func (g *group[T]) GoKeyed(key string, f FnT[T]) {
+	if g.fulled {
+		panic("taskgroup is fulled, more task will be rejected.")
+	}
+
+	if g.closing.Load() {
+		return
+	}
+
+	g.lock.Lock()
+	if g.keyed == nil {
+		g.keyed = make(map[string]*inflight[T])
+	}
+	if inf, ok := g.keyed[key]; ok {
+		inf.waiters++
+		g.lock.Unlock()
+		return
+	}
+
+	inf := &inflight[T]{waiters: 1}
+	g.keyed[key] = inf
+	g.lock.Unlock()
+
+	g.taskWg.Add(1)
+
+	if g.lmt != nil {
+		g.lmt <- struct{}{}
+	}
+
+	idx := int(atomic.AddUint32(&g.taskIdx, 1)) - 1
+	go g.doKeyed(key, inf, f, idx)
+}
+
+// It executes the keyed task once and fans its payload out to every caller
+// that shared the key. Every extra copy goes through deliver, the same
+// MaxErrorCount/CollectAllErrors gating and cancellation path handle uses,
+// so a shared error is counted once per caller instead of bypassing the
+// threshold entirely; the final copy goes through handle itself so taskWg
+// accounting stays tied to the single underlying task.
+func (g *group[T]) doKeyed(key string, inf *inflight[T], f FnT[T], idx int) {
+	data, err := g.run(f, idx)
+
+	if g.lmt != nil {
+		defer func() {
+			<-g.lmt
+		}()
+	}
+
+	g.lock.Lock()
+	extra := inf.waiters - 1
+	delete(g.keyed, key)
+	g.lock.Unlock()
+
+	p := &PayloadT[T]{Data: data, Err: err, Shared: extra > 0}
+
+	for i := 0; i < extra; i++ {
+		g.deliver(p)
+	}
+
+	g.handle(p)
+}
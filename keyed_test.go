@@ -0,0 +1,94 @@
+package taskgroup
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// taskgroup GoKeyed dedup test
+func TestGoKeyedDedup(t *testing.T) {
+
+	loop(func() {
+
+		var calls int32
+		n := 10
+		g := NewT[int](&Option{Limit: 5})
+
+		for i := 0; i < n; i++ {
+			g.GoKeyed("warm-cache", func() (int, error) {
+				atomic.AddInt32(&calls, 1)
+				return delay(10)
+			})
+		}
+
+		g.Fed()
+
+		count := 0
+		shared := 0
+		for p := range g.Result() {
+			assert.NoError(t, p.Err)
+			assert.Equal(t, 10, p.Data)
+			if p.Shared {
+				shared++
+			}
+			count++
+		}
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		assert.Equal(t, n, count)
+		assert.Equal(t, n, shared)
+	})
+}
+
+// taskgroup GoKeyed fan-out copies respect MaxErrorCount like plain Go does
+func TestGoKeyedMaxErrorCount(t *testing.T) {
+
+	loop(func() {
+
+		n := 5
+		g := New(&Option{MaxErrorCount: 1})
+
+		for i := 0; i < n; i++ {
+			g.GoKeyed("same-key", func() (interface{}, error) {
+				return nil, expectedError
+			})
+		}
+
+		g.Fed()
+
+		count := 0
+		for p := range g.Result() {
+			assert.Equal(t, expectedError, p.Err)
+			count++
+		}
+		assert.Equal(t, 1, count)
+	})
+}
+
+// taskgroup GoKeyed with distinct keys runs independently
+func TestGoKeyedDistinctKeys(t *testing.T) {
+
+	loop(func() {
+
+		g := NewT[int](&Option{Limit: 5})
+
+		g.GoKeyed("a", func() (int, error) {
+			return delay(1)
+		})
+		g.GoKeyed("b", func() (int, error) {
+			return delay(2)
+		})
+
+		g.Fed()
+
+		sum := 0
+		for p := range g.Result() {
+			assert.NoError(t, p.Err)
+			assert.False(t, p.Shared)
+			sum += p.Data
+		}
+		assert.Equal(t, 3, sum)
+	})
+}
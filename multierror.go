@@ -0,0 +1,46 @@
+package taskgroup
+
+import "strings"
+
+// MultiError aggregates every error collected by Wait when
+// Option.CollectAllErrors is set and more than one task fails, modeled on
+// facebookgo/errgroup's MultiError.
+type MultiError []error
+
+// Error joins the underlying errors with " | ".
+func (m MultiError) Error() string {
+	strs := make([]string, len(m))
+	for i, err := range m {
+		strs[i] = err.Error()
+	}
+	return strings.Join(strs, " | ")
+}
+
+// Unwrap exposes the underlying errors for errors.Is/As.
+func (m MultiError) Unwrap() []error {
+	return []error(m)
+}
+
+// Wait drains Result() until Fed() has been called and every task has
+// finished. It returns nil when no task errored, the single error when
+// exactly one did, and a MultiError when several did.
+//
+// Wait is an alternative to ranging over Result() yourself; don't do both,
+// since only one consumer can drain the channel.
+func (g *group[T]) Wait() error {
+	var errs []error
+	for p := range g.Result() {
+		if p.Err != nil {
+			errs = append(errs, p.Err)
+		}
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return MultiError(errs)
+	}
+}
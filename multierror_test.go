@@ -0,0 +1,100 @@
+package taskgroup
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// taskgroup Wait with no errors
+func TestWaitNoError(t *testing.T) {
+
+	loop(func() {
+
+		g := New()
+
+		for i := 0; i < 5; i++ {
+			g.Go(func() (interface{}, error) {
+				return delay(0)
+			})
+		}
+
+		g.Fed()
+
+		assert.NoError(t, g.Wait())
+	})
+}
+
+// taskgroup Wait with a single error
+func TestWaitSingleError(t *testing.T) {
+
+	loop(func() {
+
+		g := New()
+
+		g.Go(func() (interface{}, error) {
+			return nil, expectedError
+		})
+
+		g.Fed()
+
+		assert.Equal(t, expectedError, g.Wait())
+	})
+}
+
+// taskgroup Wait with CollectAllErrors aggregates every error
+func TestWaitCollectAllErrors(t *testing.T) {
+
+	loop(func() {
+
+		n := 5
+		g := New(&Option{CollectAllErrors: true})
+
+		for i := 0; i < n; i++ {
+			g.Go(func() (interface{}, error) {
+				return nil, expectedError
+			})
+		}
+
+		g.Fed()
+
+		err := g.Wait()
+
+		var multi MultiError
+		assert.True(t, errors.As(err, &multi))
+		assert.Len(t, multi, n)
+		for _, e := range multi {
+			assert.Equal(t, expectedError, e)
+		}
+	})
+}
+
+// taskgroup MaxErrorCount still cancels the group once reached even with
+// CollectAllErrors set; CollectAllErrors only keeps every payload past the
+// threshold from being dropped, it does not disable the cancellation.
+func TestMaxErrorCountCancelsWithCollectAllErrors(t *testing.T) {
+
+	loop(func() {
+
+		n := 5
+		g := New(&Option{MaxErrorCount: 1, CollectAllErrors: true})
+
+		for i := 0; i < n; i++ {
+			g.Go(func() (interface{}, error) {
+				return nil, expectedError
+			})
+		}
+
+		g.Fed()
+
+		count := 0
+		for p := range g.Result() {
+			assert.Equal(t, expectedError, p.Err)
+			count++
+		}
+		assert.Equal(t, n, count)
+
+		assert.Error(t, g.Ctx().Err())
+	})
+}
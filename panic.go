@@ -0,0 +1,17 @@
+package taskgroup
+
+import "fmt"
+
+// PanicError is the structured form of a task panic recovered by the group,
+// delivered as Payload.Err so callers can inspect Value and Stack instead of
+// parsing a formatted string.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// Error keeps the historical "taskgroup: panic recovered: ..." string form
+// for backward compatibility.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("taskgroup: panic recovered: %s\n%s", e.Value, e.Stack)
+}
@@ -0,0 +1,44 @@
+package taskgroup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// taskgroup PanicError and OnPanic hook test
+func TestPanicErrorAndOnPanic(t *testing.T) {
+
+	loop(func() {
+
+		var gotIndex int
+		var gotRecovered interface{}
+		var gotStack []byte
+
+		g := New(&Option{OnPanic: func(taskIndex int, recovered interface{}, stack []byte) {
+			gotIndex = taskIndex
+			gotRecovered = recovered
+			gotStack = stack
+		}})
+
+		g.Go(func() (interface{}, error) {
+			panic("boom")
+		})
+
+		g.Fed()
+
+		var err error
+		for p := range g.Result() {
+			err = p.Err
+		}
+
+		var pe *PanicError
+		assert.ErrorAs(t, err, &pe)
+		assert.Equal(t, "boom", pe.Value)
+		assert.Contains(t, pe.Error(), "taskgroup: panic recovered:")
+
+		assert.Equal(t, 0, gotIndex)
+		assert.Equal(t, "boom", gotRecovered)
+		assert.NotEmpty(t, gotStack)
+	})
+}
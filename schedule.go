@@ -0,0 +1,195 @@
+package taskgroup
+
+import (
+	"container/heap"
+	"sync/atomic"
+	"time"
+)
+
+// schedItem is one task queued through GoAt/GoAfter, waiting for its turn.
+// taskIdx is its submission order, forwarded to run for Option.OnPanic.
+// heapIdx tracks its current position in schedHeap so GoAt can pull an
+// item back out with heap.Remove after a push races a cancellation.
+type schedItem[T any] struct {
+	when    time.Time
+	f       FnT[T]
+	taskIdx int
+	heapIdx int
+}
+
+// schedHeap is a min-heap of schedItem ordered by when, modeled on the
+// scheduling queue in schedgroup.
+type schedHeap[T any] []*schedItem[T]
+
+func (h schedHeap[T]) Len() int { return len(h) }
+
+func (h schedHeap[T]) Less(i, j int) bool { return h[i].when.Before(h[j].when) }
+
+func (h schedHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].heapIdx = i
+	h[j].heapIdx = j
+}
+
+func (h *schedHeap[T]) Push(x any) {
+	item := x.(*schedItem[T])
+	item.heapIdx = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *schedHeap[T]) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.heapIdx = -1
+	*h = old[:n-1]
+	return item
+}
+
+// GoAt queues f to run at t, while still respecting Option.Limit,
+// cancellation, panic recovery and result delivery through Result(). If
+// the group is cancelled before t arrives, f is never called and a
+// Payload carrying the group's context error is delivered instead.
+//
+// If you call it after calling Fed method, task will be rejected and panic will occur!!!
+func (g *group[T]) GoAt(t time.Time, f FnT[T]) {
+	if g.fulled {
+		panic("taskgroup is fulled, more task will be rejected.")
+	}
+
+	if g.closing.Load() {
+		return
+	}
+
+	g.taskWg.Add(1)
+	g.startDispatcher()
+
+	idx := int(atomic.AddUint32(&g.taskIdx, 1)) - 1
+
+	g.schedLock.Lock()
+	item := &schedItem[T]{when: t, f: f, taskIdx: idx}
+	heap.Push(&g.sched, item)
+
+	// g.closing above can still read false for a task that's already
+	// cancelled: it's set by a separate goroutine that propagates ctx
+	// cancellation, and the dispatcher reacts to g.ctx.Done() directly --
+	// it can drain sched and exit for good, never to restart, before that
+	// goroutine gets scheduled. A push that lands in that window would
+	// otherwise sit in sched forever with nothing left alive to run or
+	// drain it, and Fed() would wait on its taskWg.Add(1) forever. Re-check
+	// under the same lock the dispatcher uses to drain, and if we lost the
+	// race, pull the item back out and deliver the cancellation ourselves.
+	//
+	// handle must run on its own goroutine here, not inline: it blocks
+	// sending on the unbuffered ret channel, and GoAt can be called before
+	// Fed() starts a consumer, same as do() and runScheduled already do.
+	if err := g.ctx.Err(); err != nil {
+		heap.Remove(&g.sched, item.heapIdx)
+		g.schedLock.Unlock()
+		go g.handle(&PayloadT[T]{Err: err})
+		return
+	}
+
+	soonest := g.sched[0] == item
+	g.schedLock.Unlock()
+
+	if soonest {
+		select {
+		case g.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// GoAfter queues f to run after d has elapsed. It is shorthand for
+// GoAt(time.Now().Add(d), f).
+func (g *group[T]) GoAfter(d time.Duration, f FnT[T]) {
+	g.GoAt(time.Now().Add(d), f)
+}
+
+// It lazily starts the single dispatcher goroutine the first time a
+// scheduled task is queued.
+func (g *group[T]) startDispatcher() {
+	g.schedOnce.Do(func() {
+		g.wake = make(chan struct{}, 1)
+		go g.dispatch()
+	})
+}
+
+// It sleeps on the earliest deadline in sched, firing tasks as they come
+// due and waking early whenever a sooner task is inserted.
+func (g *group[T]) dispatch() {
+	for {
+		g.schedLock.Lock()
+		var timer *time.Timer
+		if len(g.sched) > 0 {
+			if d := time.Until(g.sched[0].when); d <= 0 {
+				item := heap.Pop(&g.sched).(*schedItem[T])
+				g.schedLock.Unlock()
+				g.runScheduled(item)
+				continue
+			} else {
+				timer = time.NewTimer(d)
+			}
+		}
+		g.schedLock.Unlock()
+
+		if timer == nil {
+			select {
+			case <-g.wake:
+			case <-g.ctx.Done():
+				g.drainScheduled()
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-timer.C:
+		case <-g.wake:
+			timer.Stop()
+		case <-g.ctx.Done():
+			timer.Stop()
+			g.drainScheduled()
+			return
+		}
+	}
+}
+
+// It hands a due task to the normal Go path, still gated by Option.Limit.
+// Acquiring that slot also races against g.ctx.Done(), so a task waiting
+// on a full limiter is cancelled promptly instead of blocking the single
+// dispatcher goroutine -- and with it every other task still in sched --
+// until the limiter eventually frees up.
+func (g *group[T]) runScheduled(item *schedItem[T]) {
+	if g.closing.Load() {
+		g.handle(&PayloadT[T]{Err: g.ctx.Err()})
+		return
+	}
+
+	if g.lmt != nil {
+		select {
+		case g.lmt <- struct{}{}:
+		case <-g.ctx.Done():
+			g.handle(&PayloadT[T]{Err: g.ctx.Err()})
+			return
+		}
+	}
+
+	go g.do(item.f, item.taskIdx)
+}
+
+// It drains every task still waiting in sched when the group is cancelled,
+// delivering the group's context error for each so Result() accounts for
+// them consistently instead of leaving Fed() waiting forever.
+func (g *group[T]) drainScheduled() {
+	g.schedLock.Lock()
+	items := g.sched
+	g.sched = nil
+	g.schedLock.Unlock()
+
+	for range items {
+		g.handle(&PayloadT[T]{Err: g.ctx.Err()})
+	}
+}
@@ -0,0 +1,177 @@
+package taskgroup
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// taskgroup GoAfter orders tasks by their delay, not submission order.
+//
+// Unlike the other tests in this file, this one does not run inside loop():
+// exact scheduling order isn't guaranteed once tens of thousands of
+// goroutines are contending for the CPU.
+func TestGoAfterOrdering(t *testing.T) {
+
+	g := NewT[int](&Option{Limit: 5})
+
+	g.GoAfter(30*time.Millisecond, func() (int, error) { return 3, nil })
+	g.GoAfter(10*time.Millisecond, func() (int, error) { return 1, nil })
+	g.GoAfter(20*time.Millisecond, func() (int, error) { return 2, nil })
+
+	g.Fed()
+
+	var got []int
+	for p := range g.Result() {
+		assert.NoError(t, p.Err)
+		got = append(got, p.Data)
+	}
+	assert.Equal(t, []int{1, 2, 3}, got)
+}
+
+// taskgroup GoAt cancellation must not block on a full Option.Limit: a
+// scheduled task that comes due while every slot is held by a long-running
+// task should be cancelled promptly instead of waiting for that task to
+// finish and free a slot, and so should every other task still in sched.
+func TestGoAtCancelDoesNotBlockOnLimit(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	g, _ := WithContextT[int](ctx, &Option{Limit: 1})
+
+	holding := make(chan struct{})
+	g.Go(func() (int, error) {
+		close(holding)
+		time.Sleep(300 * time.Millisecond)
+		return 0, nil
+	})
+	<-holding
+
+	g.GoAt(time.Now(), func() (int, error) { return 1, nil })
+	g.GoAt(time.Now().Add(time.Hour), func() (int, error) { return 2, nil })
+
+	// give the dispatcher time to actually be blocked trying to acquire the
+	// limiter for the due task, instead of racing Cancel against it.
+	time.Sleep(50 * time.Millisecond)
+
+	g.Cancel()
+	g.Fed()
+
+	cancelled := 0
+	deadline := time.After(200 * time.Millisecond)
+loop:
+	for {
+		select {
+		case p, ok := <-g.Result():
+			if !ok {
+				break loop
+			}
+			if p.Err != nil {
+				assert.ErrorIs(t, p.Err, context.Canceled)
+				cancelled++
+			}
+		case <-deadline:
+			break loop
+		}
+	}
+
+	assert.Equal(t, 2, cancelled)
+}
+
+// taskgroup GoAt must not lose a race against cancellation: the dispatcher
+// selects on g.ctx.Done() directly, so it can drain sched and exit for good
+// before the separate goroutine that propagates cancellation into g.closing
+// has run. A GoAt call that reads g.closing as still false in that window
+// pushes a new item after the dispatcher has already drained and exited --
+// nothing is left alive to run or drain it, leaking a taskWg.Add(1) that
+// Fed() waits on forever and hangs Result().
+//
+// The window is narrow and timing-dependent, so this polls for the exact
+// state it needs (sched drained, g.closing not yet observed true) instead
+// of relying on goroutine scheduling luck, and repeats until it has caught
+// the window often enough to trust a pass.
+func TestGoAtAfterDispatcherDrainsBeforeClosingObserved(t *testing.T) {
+
+	old := runtime.GOMAXPROCS(8)
+	defer runtime.GOMAXPROCS(old)
+
+	caught := 0
+	for i := 0; i < 5000 && caught < 10; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		g, _ := WithContextT[int](ctx, &Option{Limit: 5})
+		gi := g.(*group[int])
+
+		gi.GoAt(time.Now().Add(time.Hour), func() (int, error) { return 0, nil })
+		cancel()
+
+		hitWindow := false
+		for j := 0; j < 200000; j++ {
+			gi.schedLock.Lock()
+			drained := len(gi.sched) == 0
+			gi.schedLock.Unlock()
+
+			if drained && !gi.closing.Load() {
+				hitWindow = true
+				break
+			}
+			if gi.closing.Load() {
+				break
+			}
+		}
+		if hitWindow {
+			caught++
+			gi.GoAt(time.Now().Add(time.Hour), func() (int, error) { return 0, nil })
+		}
+
+		// Every attempt must drain Result() and call Fed(), hit or miss: the
+		// first GoAt's delivery is already in flight by this point, and
+		// leaving it undrained would leak a goroutine blocked forever on the
+		// unbuffered ret channel, attempt after attempt.
+		gi.Fed()
+
+		finished := make(chan struct{})
+		go func() {
+			for range gi.Result() {
+			}
+			close(finished)
+		}()
+
+		select {
+		case <-finished:
+		case <-time.After(time.Second):
+			if hitWindow {
+				t.Fatalf("attempt %d: GoAt landing after the dispatcher drained and exited hung, Result() never closed", i)
+			}
+		}
+	}
+
+	if caught == 0 {
+		t.Skip("never caught the dispatcher-drained-before-closing-observed window, cannot exercise the race")
+	}
+}
+
+// taskgroup GoAt cancellation drains pending scheduled tasks
+func TestGoAtCancelDrainsPending(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	g, _ := WithContextT[int](ctx, &Option{Limit: 5})
+
+	g.GoAt(time.Now().Add(time.Hour), func() (int, error) { return 0, nil })
+	g.GoAt(time.Now().Add(time.Hour), func() (int, error) { return 0, nil })
+
+	g.Cancel()
+	g.Fed()
+
+	count := 0
+	for p := range g.Result() {
+		assert.ErrorIs(t, p.Err, context.Canceled)
+		count++
+	}
+	assert.Equal(t, 2, count)
+}
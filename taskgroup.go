@@ -8,70 +8,132 @@ package taskgroup
 
 import (
 	"context"
-	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // A group is a collection of goroutines working on subtasks that are part of
 // the same overall task.
 //
 // The group struct is private, A zero group is invalid, getting a group must use New function.
-type group struct {
+type group[T any] struct {
 	lock      sync.Mutex
 	taskWg    sync.WaitGroup
 	closeOnce sync.Once
 
-	ret    chan *Payload
+	ret    chan *PayloadT[T]
 	ctx    context.Context
 	cancel func()
 
-	lmt    chan struct{}
-	dr     bool
-	amount uint32
-	mec    uint32
+	keyed map[string]*inflight[T]
+
+	schedLock sync.Mutex
+	sched     schedHeap[T]
+	wake      chan struct{}
+	schedOnce sync.Once
+
+	lmt         chan struct{}
+	dr          bool
+	cae         bool
+	amount      uint32
+	mec         uint32
+	taskIdx     uint32
+	onPanic     func(taskIndex int, recovered any, stack []byte)
+	taskTimeout time.Duration
 
 	fulled  bool
-	closing bool
+	closing atomic.Bool
 }
 
-// The group result struct.
-type Payload struct {
-	Data interface{}
+// PayloadT is the typed group result struct, modeled on PayloadT[T] in
+// singleflight.Group[K, V]-style generic rewrites. Payload is its
+// interface{}-flavoured counterpart, kept for backward compatibility.
+type PayloadT[T any] struct {
+	Data T
 	Err  error
+
+	// Shared is true when this payload was produced by a GoKeyed task that
+	// more than one caller submitted under the same key.
+	Shared bool
 }
 
+// Payload is the group result struct.
+//
+// It is an alias of PayloadT[any] so legacy callers keep working unchanged
+// while the implementation lives entirely in the generic core.
+type Payload = PayloadT[any]
+
 // The group configuration option
 type Option struct {
 	Limit          uint32
 	MaxErrorCount  uint32
 	DisableRecover bool
+
+	// CollectAllErrors makes the group deliver every task error on Result()
+	// instead of dropping the ones past MaxErrorCount, so Wait() can
+	// aggregate them all into a MultiError. MaxErrorCount still triggers
+	// gracefulClose once the threshold is reached; CollectAllErrors only
+	// stops payloads past that point from being discarded, it does not
+	// disable the cancellation.
+	CollectAllErrors bool
+
+	// OnPanic, when set, is invoked with the recovered value and stack trace
+	// of a panicking task before its *PanicError is delivered on Result().
+	// taskIndex is the 0-based order in which the task was submitted.
+	OnPanic func(taskIndex int, recovered any, stack []byte)
+
+	// TaskTimeout, when set, bounds each GoCtx task with its own context
+	// derived from the group's, instead of only the group-wide ctx.
+	TaskTimeout time.Duration
 }
 
-// Just alias.
-type Fn = func() (interface{}, error)
+// FnT is the typed task function, modeled on Fn.
+type FnT[T any] func() (T, error)
 
-// Group interface
-type Group interface {
+// Fn is just an alias of FnT[any], kept for backward compatibility.
+type Fn = FnT[any]
+
+// GroupT is the typed group interface.
+type GroupT[T any] interface {
 	Cancel()
-	Result() <-chan *Payload
+	Result() <-chan *PayloadT[T]
 	Ctx() context.Context
-	Go(f Fn)
+	Go(f FnT[T])
+	GoCtx(f CtxFnT[T])
+	GoKeyed(key string, f FnT[T])
+	GoAt(t time.Time, f FnT[T])
+	GoAfter(d time.Duration, f FnT[T])
 	Fed()
+	Wait() error
 }
 
+// Group is an alias of GroupT[any], kept for backward compatibility.
+type Group = GroupT[any]
+
 // It returns a new Group by options.
 func New(options ...*Option) Group {
-	g, _ := WithContext(context.Background(), options...)
-	return g
+	return NewT[any](options...)
 }
 
 // It returns a new Group and an associated Context derived from ctx.
 func WithContext(ctx context.Context, options ...*Option) (Group, context.Context) {
-	ret := make(chan *Payload)
+	return WithContextT[any](ctx, options...)
+}
+
+// NewT returns a new GroupT[T] by options.
+func NewT[T any](options ...*Option) GroupT[T] {
+	g, _ := WithContextT[T](context.Background(), options...)
+	return g
+}
+
+// WithContextT returns a new GroupT[T] and an associated Context derived from ctx.
+func WithContextT[T any](ctx context.Context, options ...*Option) (GroupT[T], context.Context) {
+	ret := make(chan *PayloadT[T])
 	ctxWithCancel, cancel := context.WithCancel(ctx)
 
-	g := &group{ret: ret, ctx: ctxWithCancel, cancel: cancel}
+	g := &group[T]{ret: ret, ctx: ctxWithCancel, cancel: cancel}
 	g.config(options)
 
 	go func() {
@@ -90,7 +152,7 @@ func WithContext(ctx context.Context, options ...*Option) (Group, context.Contex
 // It is very important that call the Fed method when you want to stop tasks producing of
 // the producer; it can terminate the consumer when all of the tasks are finished; otherwise
 // consumer will always wait for more tasks to consuming!!!
-func (g *group) Fed() {
+func (g *group[T]) Fed() {
 	g.fulled = true
 
 	go func() {
@@ -102,24 +164,25 @@ func (g *group) Fed() {
 }
 
 // It can gracefully cancel all tasks of the group.
-func (g *group) Cancel() {
+func (g *group[T]) Cancel() {
 	g.gracefulClose()
 }
 
 // It is to gracefully cancel tasks and close ret channel.
-func (g *group) gracefulClose() {
-	g.closing = true
+func (g *group[T]) gracefulClose() {
+	g.closing.Store(true)
 	g.cancel()
 }
 
 // It closes the channel.
 //
-//  The four way to close ret channel:
+//	The four way to close ret channel:
+//
 // - all of tasks finish: close channel right now ( the premise is you called g.Fed()!!! )
 // - manual cancel: must gracefully close
 // - ctx is done: must gracefully close
 // - meet error max count : must gracefully close
-func (g *group) close() {
+func (g *group[T]) close() {
 	g.closeOnce.Do(func() {
 		close(g.ret)
 	})
@@ -129,21 +192,21 @@ func (g *group) close() {
 //
 // It is to return the group result channel. You can for-range it and get data and err like following:
 //
-// 	for p := range g.Result {
-// 		if p.Err != nil {
-// 			g.Cancel()
-// 			return
-// 		}
-// 		...
+//	for p := range g.Result {
+//		if p.Err != nil {
+//			g.Cancel()
+//			return
+//		}
+//		...
 //	}
 //
 // You can cancel the all subtasks of the group or ignore it, when the error occur.
-func (g *group) Result() <-chan *Payload {
+func (g *group[T]) Result() <-chan *PayloadT[T] {
 	return g.ret
 }
 
 // It is to return the ctx of the group inner.
-func (g *group) Ctx() context.Context {
+func (g *group[T]) Ctx() context.Context {
 	return g.ctx
 }
 
@@ -152,12 +215,12 @@ func (g *group) Ctx() context.Context {
 // Go method calls the given function in a new goroutine, the goroutines number can be limited.
 //
 // If you call it after calling Fed method, task will be rejected and panic will occur!!!
-func (g *group) Go(f Fn) {
+func (g *group[T]) Go(f FnT[T]) {
 	if g.fulled {
 		panic("taskgroup is fulled, more task will be rejected.")
 	}
 
-	if g.closing {
+	if g.closing.Load() {
 		return
 	}
 
@@ -167,13 +230,14 @@ func (g *group) Go(f Fn) {
 		g.lmt <- struct{}{}
 	}
 
-	go g.do(f)
+	idx := int(atomic.AddUint32(&g.taskIdx, 1)) - 1
+	go g.do(f, idx)
 }
 
 // It will execute your function, obtain results, and send them to channel.
-func (g *group) do(f Fn) {
+func (g *group[T]) do(f FnT[T], idx int) {
 	var (
-		data interface{}
+		data T
 		err  error
 	)
 
@@ -184,57 +248,71 @@ func (g *group) do(f Fn) {
 			}
 		}()
 
-		g.handle(data, err)
+		g.handle(&PayloadT[T]{Data: data, Err: err})
 	}()
 
+	data, err = g.run(f, idx)
+}
+
+// It runs f, recovering a panic into a *PanicError unless Option.DisableRecover
+// is set, invoking Option.OnPanic beforehand if configured. idx is the task's
+// submission order, passed through to the hook.
+func (g *group[T]) run(f FnT[T], idx int) (data T, err error) {
 	if g.dr {
-		data, err = f()
-		return
+		return f()
 	}
 
-	fn := func() (data interface{}, err error) {
-		defer func() {
-			if r := recover(); r != nil {
-				buf := make([]byte, 64<<10)
-				buf = buf[:runtime.Stack(buf, false)]
-				err = fmt.Errorf("taskgroup: panic recovered: %s\n%s", r, buf)
+	defer func() {
+		if r := recover(); r != nil {
+			buf := make([]byte, 64<<10)
+			buf = buf[:runtime.Stack(buf, false)]
+
+			if g.onPanic != nil {
+				g.onPanic(idx, r, buf)
 			}
-		}()
-		return f()
-	}
-	data, err = fn()
+
+			err = &PanicError{Value: r, Stack: buf}
+		}
+	}()
+	return f()
 }
 
-// It is to send the payload to result channel.
-func (g *group) handle(data interface{}, err error) {
+// It is to send the payload to result channel, accounting for the task
+// that produced p in taskWg.
+func (g *group[T]) handle(p *PayloadT[T]) {
 	defer g.taskWg.Done()
+	g.deliver(p)
+}
 
-	if err != nil && g.mec > 0 {
-		if g.amount >= g.mec {
-			return
-		}
-
+// It applies the MaxErrorCount/CollectAllErrors gating and sends p on ret.
+// It does not touch taskWg, so GoKeyed can fan a shared payload out to
+// every caller that joined the same key through the same counting and
+// cancellation path, without double-counting the one underlying task.
+//
+// MaxErrorCount still triggers gracefulClose once reached even when
+// CollectAllErrors is set; CollectAllErrors only keeps deliver from
+// discarding payloads past that point.
+func (g *group[T]) deliver(p *PayloadT[T]) {
+	if p.Err != nil && g.mec > 0 {
 		g.lock.Lock()
 		g.amount++
-		if g.amount == g.mec {
-			g.ret <- &Payload{data, err}
+		amount := g.amount
+		g.lock.Unlock()
+
+		if amount == g.mec {
 			g.gracefulClose()
-			g.lock.Unlock()
-			return
 		}
-		g.lock.Unlock()
 
-		if g.amount > g.mec {
+		if amount > g.mec && !g.cae {
 			return
 		}
 	}
 
-	g.ret <- &Payload{data, err}
-
+	g.ret <- p
 }
 
 // It is to configure group.
-func (g *group) config(options []*Option) {
+func (g *group[T]) config(options []*Option) {
 	option := &Option{}
 	// merge options
 	for _, o := range options {
@@ -244,6 +322,15 @@ func (g *group) config(options []*Option) {
 		if o.MaxErrorCount > 0 {
 			g.mec = o.MaxErrorCount
 		}
+		if o.CollectAllErrors {
+			g.cae = o.CollectAllErrors
+		}
+		if o.OnPanic != nil {
+			g.onPanic = o.OnPanic
+		}
+		if o.TaskTimeout > 0 {
+			g.taskTimeout = o.TaskTimeout
+		}
 		if o.Limit > 0 {
 			option.Limit = o.Limit
 		}
@@ -0,0 +1,89 @@
+package taskgroup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// taskgroup typed basic test
+func TestTypedBasic(t *testing.T) {
+
+	loop(func() {
+
+		arr := []int{5, 4, 3, 2, 1}
+		g := NewT[int](&Option{Limit: 5})
+		defer g.Cancel()
+
+		for _, v := range arr {
+			v := v
+			g.Go(func() (int, error) {
+				return delay(v)
+			})
+		}
+
+		// tasks fed
+		g.Fed()
+
+		sum := 0
+		for p := range g.Result() {
+			assert.NoError(t, p.Err)
+			// no more (p.Data).(int) type assertion needed, p.Data is already an int.
+			sum += p.Data
+		}
+		assert.Equal(t, 15, sum)
+	})
+}
+
+// taskgroup typed error test
+func TestTypedError(t *testing.T) {
+
+	loop(func() {
+
+		n := 100
+		g := NewT[int](&Option{MaxErrorCount: 1})
+
+		for i := 0; i < n; i++ {
+			g.Go(func() (int, error) {
+				delay(0)
+				return 0, expectedError
+			})
+		}
+
+		// tasks fed
+		g.Fed()
+
+		count := 0
+		for p := range g.Result() {
+			count++
+			assert.Equal(t, expectedError, p.Err)
+		}
+		assert.Equal(t, 1, count)
+	})
+}
+
+// taskgroup typed WithContextT test
+func TestTypedWithContext(t *testing.T) {
+
+	loop(func() {
+
+		g, ctx := WithContextT[string](context.Background(), &Option{Limit: 2})
+		defer g.Cancel()
+
+		assert.NoError(t, ctx.Err())
+
+		g.Go(func() (string, error) {
+			return "ok", nil
+		})
+
+		g.Fed()
+
+		var got string
+		for p := range g.Result() {
+			assert.NoError(t, p.Err)
+			got = p.Data
+		}
+		assert.Equal(t, "ok", got)
+	})
+}